@@ -0,0 +1,29 @@
+// Package logx is a tiny structured-ish logger shared by setup-harbor's
+// packages, so each doesn't have to pull in a logging dependency (or
+// redefine the same helper) just to emit greppable key=value lines.
+package logx
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logf emits a structured-ish log line: a level, a message, and an even
+// number of key/value fields. This keeps output greppable without pulling
+// in a logging dependency for a single CLI tool.
+func Logf(level, msg string, kv ...any) {
+	var b strings.Builder
+	b.WriteString("level=")
+	b.WriteString(level)
+	b.WriteString(" msg=")
+	fmt.Fprintf(&b, "%q", msg)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", kv[i], kv[i+1])
+	}
+	log.Print(b.String())
+}
+
+func Info(msg string, kv ...any)  { Logf("info", msg, kv...) }
+func Warn(msg string, kv ...any)  { Logf("warn", msg, kv...) }
+func Error(msg string, kv ...any) { Logf("error", msg, kv...) }
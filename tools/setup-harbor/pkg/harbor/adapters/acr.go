@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() { Register(acrAdapter{}) }
+
+// acrAdapter registers an Azure Container Registry via a service
+// principal, read from ACR_CLIENT_ID/ACR_CLIENT_SECRET, prefixed by
+// cfg.CredentialID if set (e.g. credential_id "PROD" reads
+// PROD_ACR_CLIENT_ID/PROD_ACR_CLIENT_SECRET), so two azure-acr registries
+// for different tenants don't collide.
+type acrAdapter struct{}
+
+func (acrAdapter) Type() string       { return "azure-acr" }
+func (acrAdapter) DefaultURL() string { return "" }
+
+func (acrAdapter) Validate(cfg Config) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("azure-acr: url is required, e.g. https://<registry>.azurecr.io")
+	}
+	return nil
+}
+
+func (acrAdapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	prefix := envPrefix(cfg.CredentialID)
+	clientID, ok := env.Get(prefix + "ACR_CLIENT_ID")
+	if !ok {
+		return nil, fmt.Errorf("azure-acr: %sACR_CLIENT_ID is not set", prefix)
+	}
+	clientSecret, ok := env.Get(prefix + "ACR_CLIENT_SECRET")
+	if !ok {
+		return nil, fmt.Errorf("azure-acr: %sACR_CLIENT_SECRET is not set", prefix)
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    clientID,
+		AccessSecret: clientSecret,
+	}, nil
+}
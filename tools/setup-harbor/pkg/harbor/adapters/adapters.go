@@ -0,0 +1,81 @@
+// Package adapters builds the Harbor registry fields (URL, type,
+// credential) for each upstream registry type Harbor supports, so that
+// harborboot never needs a type switch over registry types and adding a
+// new upstream is a new file, not a new branch.
+package adapters
+
+import (
+	"os"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+// Config is the subset of a registry's declarative config an adapter
+// needs to validate itself and resolve its credential.
+type Config struct {
+	Name         string
+	URL          string
+	CredentialID string
+	Insecure     bool
+}
+
+// Env abstracts where adapters read credential material from: process
+// environment variables, or files such as a mounted Kubernetes Secret
+// volume (e.g. a GCP service-account JSON key).
+type Env interface {
+	Get(key string) (string, bool)
+	ReadFile(path string) ([]byte, error)
+}
+
+// envPrefix returns the env var prefix an adapter should use when reading
+// a single hardcoded credential var (e.g. "AWS_ACCESS_KEY_ID"), so that two
+// registries of the same type with different CredentialIDs resolve to
+// different env vars. An empty CredentialID keeps the bare var name for
+// backward compatibility with single-account setups.
+func envPrefix(credentialID string) string {
+	if credentialID == "" {
+		return ""
+	}
+	return credentialID + "_"
+}
+
+// OSEnv reads from the process environment and the local filesystem.
+type OSEnv struct{}
+
+// Get implements Env.
+func (OSEnv) Get(key string) (string, bool) { return os.LookupEnv(key) }
+
+// ReadFile implements Env.
+func (OSEnv) ReadFile(path string) ([]byte, error) { return os.ReadFile(path) }
+
+// RegistryAdapter builds the Harbor registry fields for one upstream
+// registry type.
+type RegistryAdapter interface {
+	// Type is the Harbor registry type string, e.g. "aws-ecr".
+	Type() string
+	// DefaultURL is used when a config entry omits url. Adapters with no
+	// sensible default (e.g. ones that always point at a private
+	// account-specific endpoint) return "".
+	DefaultURL() string
+	// Validate checks cfg is well-formed for this adapter.
+	Validate(cfg Config) error
+	// BuildCredential resolves this registry's credential via env,
+	// never from a value baked into the config file itself. A nil
+	// credential means Harbor should reach the registry without one
+	// (e.g. AWS IRSA or GCP Workload Identity).
+	BuildCredential(env Env, cfg Config) (*client.Credential, error)
+}
+
+var registered = map[string]RegistryAdapter{}
+
+// Register adds an adapter to the default registry, keyed by its Type().
+// Adapters call this from an init() in their own file.
+func Register(a RegistryAdapter) {
+	registered[a.Type()] = a
+}
+
+// Lookup returns the adapter registered for typ, if any.
+func Lookup(typ string) (RegistryAdapter, bool) {
+	a, ok := registered[typ]
+	return a, ok
+}
@@ -0,0 +1,195 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+type fakeEnv map[string]string
+
+func (f fakeEnv) Get(key string) (string, bool) {
+	v, ok := f[key]
+	return v, ok
+}
+
+func (f fakeEnv) ReadFile(path string) ([]byte, error) {
+	v, ok := f[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return []byte(v), nil
+}
+
+func TestAdapters(t *testing.T) {
+	cases := []struct {
+		name     string
+		typ      string
+		cfg      Config
+		env      fakeEnv
+		wantErr  bool // from Validate
+		wantCred *client.Credential
+		credErr  bool // from BuildCredential
+	}{
+		{
+			name:     "aws-ecr with static keys",
+			typ:      "aws-ecr",
+			cfg:      Config{Name: "ecr", URL: "https://123.dkr.ecr.us-east-1.amazonaws.com"},
+			env:      fakeEnv{"AWS_ACCESS_KEY_ID": "AKIAEXAMPLE", "AWS_SECRET_ACCESS_KEY": "s3cr3t"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "AKIAEXAMPLE", AccessSecret: "s3cr3t"},
+		},
+		{
+			name: "aws-ecr falls back to IRSA",
+			typ:  "aws-ecr",
+			cfg:  Config{Name: "ecr", URL: "https://123.dkr.ecr.us-east-1.amazonaws.com"},
+			env:  fakeEnv{},
+		},
+		{
+			name:    "aws-ecr missing url",
+			typ:     "aws-ecr",
+			cfg:     Config{Name: "ecr"},
+			env:     fakeEnv{},
+			wantErr: true,
+		},
+		{
+			name:     "google-gcr with service account key file",
+			typ:      "google-gcr",
+			cfg:      Config{Name: "gcr", URL: "https://gcr.io", CredentialID: "/secrets/gcr.json"},
+			env:      fakeEnv{"/secrets/gcr.json": `{"type":"service_account"}`},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "_json_key", AccessSecret: `{"type":"service_account"}`},
+		},
+		{
+			name: "google-gar falls back to workload identity",
+			typ:  "google-gar",
+			cfg:  Config{Name: "gar", URL: "https://pkg.dev"},
+			env:  fakeEnv{},
+		},
+		{
+			name:     "aws-ecr with credential_id reads namespaced env vars",
+			typ:      "aws-ecr",
+			cfg:      Config{Name: "ecr-prod", URL: "https://123.dkr.ecr.us-east-1.amazonaws.com", CredentialID: "PROD"},
+			env:      fakeEnv{"PROD_AWS_ACCESS_KEY_ID": "AKIAPROD", "PROD_AWS_SECRET_ACCESS_KEY": "prod-secret", "AWS_ACCESS_KEY_ID": "AKIAEXAMPLE", "AWS_SECRET_ACCESS_KEY": "s3cr3t"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "AKIAPROD", AccessSecret: "prod-secret"},
+		},
+		{
+			name:     "azure-acr",
+			typ:      "azure-acr",
+			cfg:      Config{Name: "acr", URL: "https://x.azurecr.io"},
+			env:      fakeEnv{"ACR_CLIENT_ID": "client-id", "ACR_CLIENT_SECRET": "client-secret"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "client-id", AccessSecret: "client-secret"},
+		},
+		{
+			name:     "azure-acr with credential_id reads namespaced env vars",
+			typ:      "azure-acr",
+			cfg:      Config{Name: "acr-prod", URL: "https://x.azurecr.io", CredentialID: "PROD"},
+			env:      fakeEnv{"PROD_ACR_CLIENT_ID": "prod-client-id", "PROD_ACR_CLIENT_SECRET": "prod-client-secret", "ACR_CLIENT_ID": "client-id", "ACR_CLIENT_SECRET": "client-secret"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "prod-client-id", AccessSecret: "prod-client-secret"},
+		},
+		{
+			name:    "azure-acr with credential_id missing namespaced env var",
+			typ:     "azure-acr",
+			cfg:     Config{Name: "acr-prod", URL: "https://x.azurecr.io", CredentialID: "PROD"},
+			env:     fakeEnv{"ACR_CLIENT_ID": "client-id", "ACR_CLIENT_SECRET": "client-secret"},
+			credErr: true,
+		},
+		{
+			name:     "gitlab",
+			typ:      "gitlab",
+			cfg:      Config{Name: "gl", URL: "https://registry.gitlab.com", CredentialID: "GITLAB_TOKEN"},
+			env:      fakeEnv{"GITLAB_TOKEN": "tok"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "gitlab-ci-token", AccessSecret: "tok"},
+		},
+		{
+			name:     "github-ghcr",
+			typ:      "github-ghcr",
+			cfg:      Config{Name: "ghcr", URL: "https://ghcr.io", CredentialID: "GHCR_TOKEN"},
+			env:      fakeEnv{"GHCR_TOKEN": "tok"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "token", AccessSecret: "tok"},
+		},
+		{
+			name:     "generic-v2",
+			typ:      "generic-v2",
+			cfg:      Config{Name: "gen", URL: "https://registry.example.com", CredentialID: "GENERIC"},
+			env:      fakeEnv{"GENERIC_USERNAME": "u", "GENERIC_PASSWORD": "p"},
+			wantCred: &client.Credential{Type: "basic", AccessKey: "u", AccessSecret: "p"},
+		},
+		{
+			name:    "generic-v2 missing credential env var",
+			typ:     "generic-v2",
+			cfg:     Config{Name: "gen", URL: "https://registry.example.com", CredentialID: "GENERIC"},
+			env:     fakeEnv{},
+			credErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter, ok := Lookup(tc.typ)
+			if !ok {
+				t.Fatalf("no adapter registered for type %q", tc.typ)
+			}
+
+			err := adapter.Validate(tc.cfg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Validate: expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			cred, err := adapter.BuildCredential(tc.env, tc.cfg)
+			if tc.credErr {
+				if err == nil {
+					t.Fatalf("BuildCredential: expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildCredential: %v", err)
+			}
+			if !equalCredential(cred, tc.wantCred) {
+				t.Fatalf("BuildCredential = %+v, want %+v", cred, tc.wantCred)
+			}
+
+			// A fake Harbor server confirms the resolved credential is
+			// what actually gets POSTed to /registries.
+			var captured client.Registry
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+					t.Errorf("decode request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer srv.Close()
+
+			c := client.New(srv.URL)
+			err = c.CreateRegistry(context.Background(), client.Registry{
+				Name:       tc.cfg.Name,
+				URL:        tc.cfg.URL,
+				Type:       tc.typ,
+				Credential: cred,
+			})
+			if err != nil {
+				t.Fatalf("CreateRegistry: %v", err)
+			}
+			if !equalCredential(captured.Credential, cred) {
+				t.Fatalf("Harbor received credential %+v, want %+v", captured.Credential, cred)
+			}
+		})
+	}
+}
+
+func equalCredential(a, b *client.Credential) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() { Register(ecrAdapter{}) }
+
+// ecrAdapter registers an AWS ECR registry. A static credential is built
+// from the standard AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY env vars,
+// prefixed by cfg.CredentialID if set (e.g. credential_id "PROD" reads
+// PROD_AWS_ACCESS_KEY_ID/PROD_AWS_SECRET_ACCESS_KEY), so two aws-ecr
+// registries for different accounts don't collide. If neither var is set,
+// no credential is sent and Harbor is expected to reach ECR via an
+// IRSA-assumed role on the node.
+type ecrAdapter struct{}
+
+func (ecrAdapter) Type() string       { return "aws-ecr" }
+func (ecrAdapter) DefaultURL() string { return "" }
+
+func (ecrAdapter) Validate(cfg Config) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("aws-ecr: url is required, e.g. https://<account>.dkr.ecr.<region>.amazonaws.com")
+	}
+	return nil
+}
+
+func (ecrAdapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	prefix := envPrefix(cfg.CredentialID)
+	accessKey, hasKey := env.Get(prefix + "AWS_ACCESS_KEY_ID")
+	accessSecret, hasSecret := env.Get(prefix + "AWS_SECRET_ACCESS_KEY")
+	if !hasKey || !hasSecret {
+		return nil, nil
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    accessKey,
+		AccessSecret: accessSecret,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() { Register(genericV2Adapter{}) }
+
+// genericV2Adapter registers any plain Docker Registry HTTP API V2
+// upstream that doesn't need a more specific adapter. cfg.CredentialID is
+// a prefix; the username and password are read from
+// "<prefix>_USERNAME"/"<prefix>_PASSWORD".
+type genericV2Adapter struct{}
+
+func (genericV2Adapter) Type() string       { return "generic-v2" }
+func (genericV2Adapter) DefaultURL() string { return "" }
+
+func (genericV2Adapter) Validate(cfg Config) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("generic-v2: url is required")
+	}
+	return nil
+}
+
+func (genericV2Adapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	if cfg.CredentialID == "" {
+		return nil, nil
+	}
+	username, ok := env.Get(cfg.CredentialID + "_USERNAME")
+	if !ok {
+		return nil, fmt.Errorf("generic-v2: env var %q is not set", cfg.CredentialID+"_USERNAME")
+	}
+	password, ok := env.Get(cfg.CredentialID + "_PASSWORD")
+	if !ok {
+		return nil, fmt.Errorf("generic-v2: env var %q is not set", cfg.CredentialID+"_PASSWORD")
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    username,
+		AccessSecret: password,
+	}, nil
+}
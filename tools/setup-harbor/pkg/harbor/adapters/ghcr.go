@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() { Register(ghcrAdapter{}) }
+
+// ghcrAdapter registers GitHub Container Registry. cfg.CredentialID names
+// the env var holding a PAT with read:packages scope.
+type ghcrAdapter struct{}
+
+func (ghcrAdapter) Type() string       { return "github-ghcr" }
+func (ghcrAdapter) DefaultURL() string { return "https://ghcr.io" }
+
+func (ghcrAdapter) Validate(cfg Config) error {
+	if cfg.CredentialID == "" {
+		return fmt.Errorf("github-ghcr: credential_id is required, naming the env var holding the registry token")
+	}
+	return nil
+}
+
+func (ghcrAdapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	token, ok := env.Get(cfg.CredentialID)
+	if !ok {
+		return nil, fmt.Errorf("github-ghcr: env var %q is not set", cfg.CredentialID)
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    "token",
+		AccessSecret: token,
+	}, nil
+}
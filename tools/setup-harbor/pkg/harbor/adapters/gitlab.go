@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() { Register(gitlabAdapter{}) }
+
+// gitlabAdapter registers a GitLab container registry. cfg.CredentialID
+// names the env var holding a deploy token or personal access token with
+// read_registry scope.
+type gitlabAdapter struct{}
+
+func (gitlabAdapter) Type() string       { return "gitlab" }
+func (gitlabAdapter) DefaultURL() string { return "https://registry.gitlab.com" }
+
+func (gitlabAdapter) Validate(cfg Config) error {
+	if cfg.CredentialID == "" {
+		return fmt.Errorf("gitlab: credential_id is required, naming the env var holding the registry token")
+	}
+	return nil
+}
+
+func (gitlabAdapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	token, ok := env.Get(cfg.CredentialID)
+	if !ok {
+		return nil, fmt.Errorf("gitlab: env var %q is not set", cfg.CredentialID)
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    "gitlab-ci-token",
+		AccessSecret: token,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+func init() {
+	Register(googleAdapter{typ: "google-gcr", defaultURL: "https://gcr.io"})
+	Register(googleAdapter{typ: "google-gar", defaultURL: "https://pkg.dev"})
+}
+
+// googleAdapter registers a Google Container/Artifact Registry. The
+// credential is the contents of a service-account JSON key, read from
+// cfg.CredentialID as a file path (typically a mounted K8s Secret volume).
+// If CredentialID is empty, no credential is sent and Harbor is expected
+// to reach Google via Workload Identity.
+type googleAdapter struct {
+	typ        string
+	defaultURL string
+}
+
+func (a googleAdapter) Type() string       { return a.typ }
+func (a googleAdapter) DefaultURL() string { return a.defaultURL }
+
+func (a googleAdapter) Validate(cfg Config) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("%s: url is required", a.typ)
+	}
+	return nil
+}
+
+func (a googleAdapter) BuildCredential(env Env, cfg Config) (*client.Credential, error) {
+	if cfg.CredentialID == "" {
+		return nil, nil
+	}
+	keyJSON, err := env.ReadFile(cfg.CredentialID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: read service account key %q: %w", a.typ, cfg.CredentialID, err)
+	}
+	return &client.Credential{
+		Type:         "basic",
+		AccessKey:    "_json_key",
+		AccessSecret: string(keyJSON),
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Package client is a typed Harbor v2.0 API client. It owns every
+// transport concern (authentication, TLS, timeouts, retries) so that
+// callers deal only with typed requests and responses, never raw
+// http.Get/http.Post calls.
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client talks to a single Harbor instance's v2.0 API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	auth       AuthHandler
+	retry      retryConfig
+}
+
+// Option configures a Client returned by New.
+type Option func(*Client)
+
+// WithAuth sets how requests are authenticated. Defaults to NoAuth.
+func WithAuth(a AuthHandler) Option {
+	return func(c *Client) { c.auth = a }
+}
+
+// WithTLSConfig configures certificate validation, e.g. to skip
+// verification or trust a custom CA for a self-signed Harbor deployment.
+func WithTLSConfig(cfg TLSConfig) Option {
+	return func(c *Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = cfg.build()
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithTimeout sets the per-request connect+read timeout. Defaults to 15s.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// WithHTTPClient overrides the underlying http.Client entirely, e.g. for
+// tests that need a custom RoundTripper.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithNoRetry disables the client's built-in retry-with-backoff for
+// transient 5xx errors, so every call surfaces the raw outcome of a
+// single attempt. Use this for callers that implement their own
+// retry/backoff loop on top (e.g. pkg/harbor/health) and need to observe
+// each probe's real result rather than have it absorbed by this layer.
+func WithNoRetry() Option {
+	return func(c *Client) { c.retry = retryConfig{maxAttempts: 1} }
+}
+
+// New returns a Client targeting baseURL, e.g.
+// "https://harbor.example.com/api/v2.0".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		auth:       NoAuth{},
+		retry:      defaultRetry,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
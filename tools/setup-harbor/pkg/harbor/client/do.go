@@ -0,0 +1,55 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// do sends a JSON request (auth, retries, and decoding all handled here)
+// and decodes a JSON response into out, if out is non-nil and the
+// response is a 2xx.
+func (c *Client) do(ctx context.Context, op, method, path string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("harbor client: %s: marshal request: %w", op, err)
+		}
+		payload = b
+	}
+
+	return withRetry(ctx, c.retry, func(err error) bool {
+		apiErr, ok := err.(*APIError)
+		return ok && isRetryableStatus(apiErr.StatusCode)
+	}, func() error {
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("harbor client: %s: build request: %w", op, err)
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		c.auth.Apply(req)
+
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("harbor client: %s: %w", op, err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode >= 300 {
+			respBody, _ := io.ReadAll(res.Body)
+			return &APIError{Op: op, StatusCode: res.StatusCode, Body: string(respBody)}
+		}
+		if out != nil {
+			if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+				return fmt.Errorf("harbor client: %s: decode response: %w", op, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,22 @@
+package client
+
+import "fmt"
+
+// APIError is returned when Harbor responds with an unexpected status
+// code. Callers can inspect StatusCode, e.g. to treat 404 as "not found"
+// rather than a failure.
+type APIError struct {
+	Op         string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("harbor client: %s: unexpected status %d: %s", e.Op, e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == 404
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Health is Harbor's aggregate health response from GET /health, broken
+// down by component (core, database, redis, registry, jobservice,
+// portal, trivy, ...).
+type Health struct {
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// ComponentHealth is the health of a single Harbor component.
+type ComponentHealth struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// GetHealth fetches Harbor's per-component health via GET /health.
+func (c *Client) GetHealth(ctx context.Context) (*Health, error) {
+	var h Health
+	if err := c.do(ctx, "GetHealth", http.MethodGet, "/health", nil, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
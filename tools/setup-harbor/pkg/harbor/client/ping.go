@@ -0,0 +1,11 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Ping checks basic Harbor API reachability via GET /ping.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, "Ping", http.MethodGet, "/ping", nil, nil)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Project is a Harbor project resource.
+type Project struct {
+	ProjectID    int              `json:"project_id,omitempty"`
+	ProjectName  string           `json:"project_name,omitempty"`
+	RegistryID   int              `json:"registry_id,omitempty"`
+	Public       bool             `json:"public,omitempty"`
+	StorageLimit int64            `json:"storage_limit,omitempty"`
+	Metadata     *ProjectMetadata `json:"metadata,omitempty"`
+}
+
+// ProjectMetadata mirrors the string-valued metadata map Harbor returns
+// for a project (e.g. GetProject's "public" field is "true"/"false").
+type ProjectMetadata struct {
+	Public string `json:"public,omitempty"`
+}
+
+// GetProject fetches a project by name. It returns a *APIError satisfying
+// IsNotFound if the project does not exist.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	var p Project
+	if err := c.do(ctx, "GetProject", http.MethodGet, "/projects/"+name, nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateProject creates a new project.
+func (c *Client) CreateProject(ctx context.Context, p Project) error {
+	return c.do(ctx, "CreateProject", http.MethodPost, "/projects", p, nil)
+}
+
+// UpdateProject updates fields of an existing project by id.
+func (c *Client) UpdateProject(ctx context.Context, id int, p Project) error {
+	return c.do(ctx, "UpdateProject", http.MethodPut, fmt.Sprintf("/projects/%d", id), p, nil)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Registry is a Harbor registry resource (an upstream the local Harbor
+// instance pulls from or pushes to).
+type Registry struct {
+	ID          int         `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	URL         string      `json:"url"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Insecure    bool        `json:"insecure,omitempty"`
+	Credential  *Credential `json:"credential,omitempty"`
+}
+
+// Credential authenticates the local Harbor instance to an upstream
+// registry. Type is one of Harbor's supported credential types, e.g.
+// "basic", "oauth", or "" for registries that need no credential.
+type Credential struct {
+	Type         string `json:"type,omitempty"`
+	AccessKey    string `json:"access_key,omitempty"`
+	AccessSecret string `json:"access_secret,omitempty"`
+}
+
+// RegistryQuery filters ListRegistries.
+type RegistryQuery struct {
+	Name string
+}
+
+// ListRegistries returns registries matching q.
+func (c *Client) ListRegistries(ctx context.Context, q RegistryQuery) ([]Registry, error) {
+	path := "/registries"
+	if q.Name != "" {
+		path += "?q=" + url.QueryEscape("name="+q.Name)
+	}
+	var registries []Registry
+	if err := c.do(ctx, "ListRegistries", http.MethodGet, path, nil, &registries); err != nil {
+		return nil, err
+	}
+	return registries, nil
+}
+
+// CreateRegistry registers a new upstream registry.
+func (c *Client) CreateRegistry(ctx context.Context, r Registry) error {
+	return c.do(ctx, "CreateRegistry", http.MethodPost, "/registries", r, nil)
+}
+
+// UpdateRegistry updates fields of an existing registry by id.
+func (c *Client) UpdateRegistry(ctx context.Context, id int, r Registry) error {
+	return c.do(ctx, "UpdateRegistry", http.MethodPut, fmt.Sprintf("/registries/%d", id), r, nil)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ReplicationPolicy is a Harbor replication policy resource. For
+// pull-based replication (mirroring an upstream registry into a local
+// project) DestRegistry is nil, which Harbor interprets as "this Harbor
+// instance".
+type ReplicationPolicy struct {
+	ID            int                 `json:"id,omitempty"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description,omitempty"`
+	SrcRegistry   *RegistryRef        `json:"src_registry,omitempty"`
+	DestRegistry  *RegistryRef        `json:"dest_registry,omitempty"`
+	DestNamespace string              `json:"dest_namespace,omitempty"`
+	Filters       []ReplicationFilter `json:"filters,omitempty"`
+	Trigger       *ReplicationTrigger `json:"trigger,omitempty"`
+	Override      bool                `json:"override"`
+	Enabled       bool                `json:"enabled"`
+	Speed         int32               `json:"speed,omitempty"`
+}
+
+// RegistryRef refers to a registry already registered with Harbor by ID.
+type RegistryRef struct {
+	ID int `json:"id"`
+}
+
+// ReplicationFilter narrows which resources a replication policy copies.
+// Type is one of Harbor's filter types, e.g. "name", "tag", "resource".
+type ReplicationFilter struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// ReplicationTrigger controls when a replication policy runs.
+type ReplicationTrigger struct {
+	// Type is "manual", "scheduled", or "event_based".
+	Type string `json:"type"`
+	// Cron is required when Type is "scheduled", e.g. "0 0 * * * *".
+	Cron string `json:"-"`
+}
+
+// MarshalJSON encodes Trigger the way Harbor's API expects: Cron nested
+// under trigger_settings rather than as a sibling field.
+func (t ReplicationTrigger) MarshalJSON() ([]byte, error) {
+	type settings struct {
+		Cron string `json:"cron,omitempty"`
+	}
+	type wire struct {
+		Type            string   `json:"type"`
+		TriggerSettings settings `json:"trigger_settings"`
+	}
+	return json.Marshal(wire{Type: t.Type, TriggerSettings: settings{Cron: t.Cron}})
+}
+
+// UnmarshalJSON decodes Harbor's nested trigger_settings.cron back into
+// Cron.
+func (t *ReplicationTrigger) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Type            string `json:"type"`
+		TriggerSettings struct {
+			Cron string `json:"cron"`
+		} `json:"trigger_settings"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	t.Type = wire.Type
+	t.Cron = wire.TriggerSettings.Cron
+	return nil
+}
+
+// ReplicationPolicyQuery filters ListReplicationPolicies.
+type ReplicationPolicyQuery struct {
+	Name string
+}
+
+// ListReplicationPolicies returns replication policies matching q.
+func (c *Client) ListReplicationPolicies(ctx context.Context, q ReplicationPolicyQuery) ([]ReplicationPolicy, error) {
+	path := "/replication/policies"
+	if q.Name != "" {
+		path += "?q=" + url.QueryEscape("name="+q.Name)
+	}
+	var policies []ReplicationPolicy
+	if err := c.do(ctx, "ListReplicationPolicies", http.MethodGet, path, nil, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// CreateReplicationPolicy creates a new replication policy.
+func (c *Client) CreateReplicationPolicy(ctx context.Context, p ReplicationPolicy) error {
+	return c.do(ctx, "CreateReplicationPolicy", http.MethodPost, "/replication/policies", p, nil)
+}
+
+// UpdateReplicationPolicy updates fields of an existing replication policy
+// by id.
+func (c *Client) UpdateReplicationPolicy(ctx context.Context, id int, p ReplicationPolicy) error {
+	return c.do(ctx, "UpdateReplicationPolicy", http.MethodPut, fmt.Sprintf("/replication/policies/%d", id), p, nil)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/internal/logx"
+)
+
+// retryConfig controls the exponential backoff used for transient Harbor
+// API failures (5xx responses). Connection errors are not retried.
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+var defaultRetry = retryConfig{
+	maxAttempts: 5,
+	baseDelay:   500 * time.Millisecond,
+	maxDelay:    10 * time.Second,
+}
+
+// withRetry runs fn up to cfg.maxAttempts times, doubling the delay
+// between attempts (capped at cfg.maxDelay), and stops early if retryable
+// returns false or ctx is done. The last error is returned if every
+// attempt fails.
+func withRetry(ctx context.Context, cfg retryConfig, retryable func(error) bool, fn func() error) error {
+	delay := cfg.baseDelay
+	var err error
+	for attempt := 1; attempt <= cfg.maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) || attempt == cfg.maxAttempts {
+			return err
+		}
+		logx.Warn("retrying harbor API call after transient error", "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > cfg.maxDelay {
+			delay = cfg.maxDelay
+		}
+	}
+	return err
+}
+
+func isRetryableStatus(status int) bool {
+	return status >= 500 && status < 600
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// TLSConfig configures how the client validates Harbor's TLS certificate.
+type TLSConfig struct {
+	// InsecureSkipVerify disables certificate verification entirely. Only
+	// intended for known-insecure dev/test Harbor instances.
+	InsecureSkipVerify bool
+	// CACertPEM, if set, is trusted in addition to the system pool. Use
+	// this for a self-signed Harbor instance instead of InsecureSkipVerify.
+	CACertPEM []byte
+}
+
+func (t TLSConfig) build() *tls.Config {
+	cfg := &tls.Config{InsecureSkipVerify: t.InsecureSkipVerify}
+	if len(t.CACertPEM) == 0 {
+		return cfg
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if pool.AppendCertsFromPEM(t.CACertPEM) {
+		cfg.RootCAs = pool
+	}
+	return cfg
+}
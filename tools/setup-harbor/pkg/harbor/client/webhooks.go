@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// WebhookPolicy is a Harbor project webhook: one or more targets notified
+// when any of EventTypes fires on the project.
+type WebhookPolicy struct {
+	ID          int             `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	ProjectID   int             `json:"project_id,omitempty"`
+	Targets     []WebhookTarget `json:"targets"`
+	EventTypes  []string        `json:"event_types"`
+	Enabled     bool            `json:"enabled"`
+}
+
+// WebhookTarget is where a webhook policy sends its notifications.
+type WebhookTarget struct {
+	// Type is the notification transport, e.g. "http" or "slack".
+	Type           string `json:"type"`
+	Address        string `json:"address"`
+	AuthHeader     string `json:"auth_header,omitempty"`
+	SkipCertVerify bool   `json:"skip_cert_verify"`
+	// Secret is the HMAC secret Harbor uses to sign each payload, sent in
+	// the "X-Harbor-Signature" request header.
+	Secret string `json:"secret,omitempty"`
+}
+
+// ListWebhookPolicies returns every webhook policy configured on the given
+// project.
+func (c *Client) ListWebhookPolicies(ctx context.Context, projectIDOrName string) ([]WebhookPolicy, error) {
+	var policies []WebhookPolicy
+	path := fmt.Sprintf("/projects/%s/webhook/policies", projectIDOrName)
+	if err := c.do(ctx, "ListWebhookPolicies", http.MethodGet, path, nil, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// CreateWebhookPolicy adds a new webhook policy to the given project.
+func (c *Client) CreateWebhookPolicy(ctx context.Context, projectIDOrName string, p WebhookPolicy) error {
+	path := fmt.Sprintf("/projects/%s/webhook/policies", projectIDOrName)
+	return c.do(ctx, "CreateWebhookPolicy", http.MethodPost, path, p, nil)
+}
+
+// UpdateWebhookPolicy updates fields of an existing webhook policy by id.
+func (c *Client) UpdateWebhookPolicy(ctx context.Context, projectIDOrName string, id int, p WebhookPolicy) error {
+	path := fmt.Sprintf("/projects/%s/webhook/policies/%d", projectIDOrName, id)
+	return c.do(ctx, "UpdateWebhookPolicy", http.MethodPut, path, p, nil)
+}
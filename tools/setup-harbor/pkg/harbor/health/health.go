@@ -0,0 +1,121 @@
+// Package health waits for a Harbor instance to come up and stay up,
+// replacing a bare /ping busy-loop with a probe of Harbor's richer
+// /health endpoint and a requirement that it stay healthy across several
+// consecutive checks before callers trust it.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+// Options configures WaitForHarbor.
+type Options struct {
+	// BaseDelay is the initial wait between probe attempts, before
+	// backoff. Defaults to 2s.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay between probe attempts. Defaults to 30s.
+	MaxDelay time.Duration
+	// ConsecutiveSuccesses is how many probes in a row must report Harbor
+	// fully healthy before WaitForHarbor returns. Defaults to 3, so a
+	// flapping component doesn't look like a clean bootstrap.
+	ConsecutiveSuccesses int
+}
+
+func (o Options) withDefaults() Options {
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = 2 * time.Second
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = 30 * time.Second
+	}
+	if o.ConsecutiveSuccesses <= 0 {
+		o.ConsecutiveSuccesses = 3
+	}
+	return o
+}
+
+// WaitForHarbor blocks until c reports opts.ConsecutiveSuccesses
+// consecutive fully healthy probes (both /ping and every /health
+// component reporting "healthy"), or ctx is done.
+//
+// c should be constructed with client.WithNoRetry(): this loop is the one
+// responsible for retry/backoff across probe attempts, and a client that
+// retries transient 5xx errors internally would absorb exactly the
+// failures this loop needs to see to reset its consecutive-success count.
+func WaitForHarbor(ctx context.Context, c *client.Client, opts Options) error {
+	opts = opts.withDefaults()
+
+	delay := opts.BaseDelay
+	consecutive := 0
+	for {
+		probeErr := probe(ctx, c)
+		if probeErr == nil {
+			consecutive++
+			logInfo("harbor probe healthy", "consecutive", consecutive, "needed", opts.ConsecutiveSuccesses)
+			if consecutive >= opts.ConsecutiveSuccesses {
+				return nil
+			}
+		} else {
+			if consecutive > 0 {
+				logWarn("harbor probe failed, resetting consecutive success count", "err", probeErr)
+			} else {
+				logWarn("harbor probe failed", "err", probeErr)
+			}
+			consecutive = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("harbor health: timed out waiting for Harbor: %w (last probe error: %v)", ctx.Err(), probeErr)
+		case <-time.After(jitter(delay)):
+		}
+		delay *= 2
+		if delay > opts.MaxDelay {
+			delay = opts.MaxDelay
+		}
+	}
+}
+
+func probe(ctx context.Context, c *client.Client) error {
+	if err := c.Ping(ctx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	h, err := c.GetHealth(ctx)
+	if err != nil {
+		return fmt.Errorf("health: %w", err)
+	}
+	return unhealthyReason(h)
+}
+
+// unhealthyReason returns a descriptive error if h reports any component
+// other than "healthy", or nil if Harbor is fully up.
+func unhealthyReason(h *client.Health) error {
+	var unhealthy []string
+	for _, comp := range h.Components {
+		if comp.Status != "healthy" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s=%s", comp.Name, comp.Status))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("components not healthy: %v", unhealthy)
+	}
+	if h.Status != "" && h.Status != "healthy" {
+		return errors.New("overall status: " + h.Status)
+	}
+	return nil
+}
+
+// jitter adds up to 50% random jitter to d so that many callers waiting
+// on the same Harbor instance don't retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
@@ -0,0 +1,150 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/client"
+)
+
+// harborStub serves /ping and /health from a sequence of canned
+// responses, one per call, repeating the last entry once exhausted.
+type harborStub struct {
+	pings   []int // HTTP status codes returned by /ping, in order
+	healths []client.Health
+
+	pingCalls   atomic.Int64
+	healthCalls atomic.Int64
+}
+
+func (s *harborStub) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			i := s.pingCalls.Add(1) - 1
+			status := s.pings[min(int(i), len(s.pings)-1)]
+			w.WriteHeader(status)
+		case "/health":
+			i := s.healthCalls.Add(1) - 1
+			h := s.healths[min(int(i), len(s.healths)-1)]
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(h)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func healthyStatus() client.Health {
+	return client.Health{
+		Status: "healthy",
+		Components: []client.ComponentHealth{
+			{Name: "core", Status: "healthy"},
+			{Name: "database", Status: "healthy"},
+			{Name: "redis", Status: "healthy"},
+			{Name: "registry", Status: "healthy"},
+			{Name: "jobservice", Status: "healthy"},
+		},
+	}
+}
+
+func TestWaitForHarbor_AlreadyHealthy(t *testing.T) {
+	stub := &harborStub{
+		pings:   []int{200},
+		healths: []client.Health{healthyStatus()},
+	}
+	srv := stub.server()
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithNoRetry())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := WaitForHarbor(ctx, c, Options{BaseDelay: time.Millisecond, ConsecutiveSuccesses: 2})
+	if err != nil {
+		t.Fatalf("WaitForHarbor: %v", err)
+	}
+	if stub.healthCalls.Load() < 2 {
+		t.Fatalf("expected at least 2 health checks to confirm consecutive success, got %d", stub.healthCalls.Load())
+	}
+}
+
+func TestWaitForHarbor_PartialComponentDown(t *testing.T) {
+	degraded := healthyStatus()
+	degraded.Status = "unhealthy"
+	degraded.Components[2] = client.ComponentHealth{Name: "redis", Status: "unhealthy", Error: "connection refused"}
+
+	stub := &harborStub{
+		pings:   []int{200},
+		healths: []client.Health{degraded, degraded, healthyStatus()},
+	}
+	srv := stub.server()
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithNoRetry())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := WaitForHarbor(ctx, c, Options{BaseDelay: time.Millisecond, ConsecutiveSuccesses: 2})
+	if err != nil {
+		t.Fatalf("WaitForHarbor: %v", err)
+	}
+	if stub.healthCalls.Load() < 4 {
+		t.Fatalf("expected WaitForHarbor to keep probing through the degraded period, got %d health calls", stub.healthCalls.Load())
+	}
+}
+
+func TestWaitForHarbor_Flapping(t *testing.T) {
+	// Healthy once, then down, then healthy again: the down probe must
+	// reset the consecutive-success counter rather than just pause it, so
+	// reaching ConsecutiveSuccesses takes more than ConsecutiveSuccesses
+	// probes overall.
+	stub := &harborStub{
+		pings:   []int{200, 503, 200, 200},
+		healths: []client.Health{healthyStatus()},
+	}
+	srv := stub.server()
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithNoRetry())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := WaitForHarbor(ctx, c, Options{BaseDelay: time.Millisecond, ConsecutiveSuccesses: 2})
+	if err != nil {
+		t.Fatalf("WaitForHarbor: %v", err)
+	}
+	if stub.pingCalls.Load() < 4 {
+		t.Fatalf("expected the flap to cost extra probes before success, got %d ping calls", stub.pingCalls.Load())
+	}
+}
+
+func TestWaitForHarbor_TimesOut(t *testing.T) {
+	stub := &harborStub{
+		pings:   []int{503},
+		healths: []client.Health{healthyStatus()},
+	}
+	srv := stub.server()
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithNoRetry())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForHarbor(ctx, c, Options{BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, ConsecutiveSuccesses: 1})
+	if err == nil {
+		t.Fatal("WaitForHarbor: expected an error when ctx times out, got nil")
+	}
+}
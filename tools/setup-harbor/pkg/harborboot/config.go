@@ -0,0 +1,99 @@
+package harborboot
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/vessl-ai/cluster-resources/tools/setup-harbor/pkg/harbor/adapters"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfig reads and validates the declarative config at path. JSON is
+// accepted too, since it is valid YAML.
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	seen := make(map[string]bool, len(c.Registries))
+	for _, r := range c.Registries {
+		if r.Name == "" {
+			return errMissingField("registries[].name")
+		}
+		if r.Type == "" {
+			return errMissingField("registries[].type")
+		}
+		if r.URL == "" && !hasDefaultURL(r.Type) {
+			return errMissingField("registries[].url")
+		}
+		if seen[r.Name] {
+			return errDuplicateName(r.Name)
+		}
+		seen[r.Name] = true
+		if r.Replication != nil {
+			if err := r.Replication.validate(); err != nil {
+				return err
+			}
+		}
+		for _, w := range r.Project.WebhookEndpoints {
+			if err := w.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var validWebhookEventTypes = map[string]bool{
+	"PUSH_ARTIFACT":      true,
+	"PULL_ARTIFACT":      true,
+	"DELETE_ARTIFACT":    true,
+	"SCANNING_COMPLETED": true,
+	"QUOTA_EXCEED":       true,
+}
+
+func (w *WebhookEndpoint) validate() error {
+	if w.URL == "" {
+		return errMissingField("registries[].project.webhook_endpoints[].url")
+	}
+	if len(w.EventTypes) == 0 {
+		return errMissingField("registries[].project.webhook_endpoints[].event_types")
+	}
+	for _, et := range w.EventTypes {
+		if !validWebhookEventTypes[et] {
+			return fmt.Errorf("registries[].project.webhook_endpoints[].event_types: unknown event type %q", et)
+		}
+	}
+	return nil
+}
+
+func (r *ReplicationConfig) validate() error {
+	switch r.Trigger.Type {
+	case "manual", "event_based":
+	case "scheduled":
+		if r.Trigger.Cron == "" {
+			return errMissingField("registries[].replication.trigger.cron")
+		}
+	default:
+		return fmt.Errorf("registries[].replication.trigger.type must be one of manual, scheduled, event_based, got %q", r.Trigger.Type)
+	}
+	return nil
+}
+
+// hasDefaultURL reports whether typ has a registered adapter that fills
+// in a default URL when a config entry omits one.
+func hasDefaultURL(typ string) bool {
+	adapter, ok := adapters.Lookup(typ)
+	return ok && adapter.DefaultURL() != ""
+}
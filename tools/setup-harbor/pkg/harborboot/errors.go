@@ -0,0 +1,25 @@
+package harborboot
+
+import "fmt"
+
+// ConfigError wraps a failure to load or validate the declarative config.
+type ConfigError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("harborboot: invalid config %q: %v", e.Path, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func errMissingField(field string) error {
+	return fmt.Errorf("missing required field %q", field)
+}
+
+func errDuplicateName(name string) error {
+	return fmt.Errorf("duplicate registry name %q", name)
+}
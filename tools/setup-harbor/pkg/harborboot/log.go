@@ -0,0 +1,7 @@
+package harborboot
+
+import "github.com/vessl-ai/cluster-resources/tools/setup-harbor/internal/logx"
+
+func logInfo(msg string, kv ...any)  { logx.Info(msg, kv...) }
+func logWarn(msg string, kv ...any)  { logx.Warn(msg, kv...) }
+func logError(msg string, kv ...any) { logx.Error(msg, kv...) }
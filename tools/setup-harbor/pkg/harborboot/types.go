@@ -0,0 +1,104 @@
+// Package harborboot reconciles a declarative Harbor configuration
+// (registries, projects, and replication policies) against the live state
+// of a Harbor instance. Unlike the bootstrap script it replaces, every run
+// is idempotent: existing state is fetched first and only the resources
+// that have drifted are written back.
+package harborboot
+
+// Config is the root of the declarative bootstrap configuration, typically
+// loaded from a YAML (or JSON, which is valid YAML) file.
+type Config struct {
+	Registries []RegistryConfig `yaml:"registries"`
+}
+
+// RegistryConfig describes one upstream registry that should be registered
+// with Harbor, along with the local project that mirrors it.
+type RegistryConfig struct {
+	Name string `yaml:"name"`
+	// Type is the Harbor registry type. Built-in values are quay,
+	// docker-hub, and harbor; aws-ecr, google-gcr, google-gar, azure-acr,
+	// gitlab, github-ghcr, and generic-v2 are resolved via the matching
+	// adapters.RegistryAdapter, which fills in DefaultURL and Credential.
+	Type         string `yaml:"type"`
+	URL          string `yaml:"url"`
+	Description  string `yaml:"description"`
+	Insecure     bool   `yaml:"insecure"`
+	CredentialID string `yaml:"credential_id"`
+
+	Project     ProjectConfig      `yaml:"project"`
+	Replication *ReplicationConfig `yaml:"replication,omitempty"`
+}
+
+// ProjectConfig describes the desired state of the local Harbor project
+// associated with a registry.
+type ProjectConfig struct {
+	Public           bool              `yaml:"public"`
+	StorageLimit     int64             `yaml:"storage_limit"`
+	RetentionPolicy  *RetentionPolicy  `yaml:"retention_policy,omitempty"`
+	RobotAccounts    []RobotAccount    `yaml:"robot_accounts,omitempty"`
+	WebhookEndpoints []WebhookEndpoint `yaml:"webhook_endpoints,omitempty"`
+}
+
+// RetentionPolicy is parsed from the config file but not reconciled:
+// harborboot does not apply it to Harbor.
+type RetentionPolicy struct {
+	Rules []map[string]any `yaml:"rules,omitempty"`
+}
+
+// RobotAccount is parsed from the config file but not reconciled; see
+// RetentionPolicy.
+type RobotAccount struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions,omitempty"`
+}
+
+// WebhookEndpoint describes a Harbor project webhook that notifies an
+// external system (a VESSL controller, CI, Slack) on project events.
+type WebhookEndpoint struct {
+	// Name identifies this webhook within the project; defaults to
+	// "webhook-<n>" (1-indexed) if omitted.
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// AuthHeader is sent as-is in the "Authorization" header of every
+	// notification request, e.g. "Bearer <token>".
+	AuthHeader     string `yaml:"auth_header,omitempty"`
+	SkipCertVerify bool   `yaml:"skip_cert_verify"`
+	// EventTypes is one or more of PUSH_ARTIFACT, PULL_ARTIFACT,
+	// DELETE_ARTIFACT, SCANNING_COMPLETED, QUOTA_EXCEED.
+	EventTypes []string `yaml:"event_types,omitempty"`
+	// HMACSecretEnv names the env var holding the secret Harbor uses to
+	// sign each notification payload, read the same way CredentialID is:
+	// never baked into the config file itself.
+	HMACSecretEnv string `yaml:"hmac_secret_env,omitempty"`
+}
+
+// ReplicationConfig describes a pull-based replication policy that mirrors
+// images from the upstream registry into this registry's local project.
+type ReplicationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// SrcFilters narrows which resources are replicated.
+	SrcFilters ReplicationFilters `yaml:"src_filters"`
+	// Trigger controls when replication runs. Type is "manual",
+	// "scheduled" (with Cron set), or "event_based".
+	Trigger ReplicationTrigger `yaml:"trigger"`
+	// DestNamespace overrides the destination project name; defaults to
+	// the registry's own name.
+	DestNamespace string `yaml:"dest_namespace"`
+	// Override replaces artifacts at the destination that already exist.
+	Override bool `yaml:"override"`
+	// Speed caps replication bandwidth in KB/s; 0 or unset means unlimited.
+	Speed int32 `yaml:"speed"`
+}
+
+// ReplicationFilters narrows a replication policy to matching resources.
+type ReplicationFilters struct {
+	NamePattern  string `yaml:"name_pattern"`
+	TagPattern   string `yaml:"tag_pattern"`
+	ResourceType string `yaml:"resource_type"`
+}
+
+// ReplicationTrigger controls when a replication policy runs.
+type ReplicationTrigger struct {
+	Type string `yaml:"type"`
+	Cron string `yaml:"cron,omitempty"`
+}